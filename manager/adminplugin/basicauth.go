@@ -0,0 +1,50 @@
+// Package adminplugin provides built-in plugins for the manager's
+// admin API plugin layer (Context.AdminPlugins), used to authenticate
+// and authorize requests against the admin HTTP API.
+package adminplugin
+
+import (
+	"net/http"
+
+	"gopkg.in/vinxi/vinxi.v0/config"
+	"gopkg.in/vinxi/vinxi.v0/plugin"
+)
+
+func init() {
+	plugin.Register(plugin.Info{
+		Name:        "basic-auth",
+		Description: "Protects the admin API with HTTP Basic authentication.",
+		Factory:     NewBasicAuth,
+	})
+}
+
+// BasicAuth implements HTTP Basic authentication for the admin API,
+// checking incoming credentials against a static username/password
+// pair supplied via config.
+type BasicAuth struct {
+	*plugin.Base
+	username string
+	password string
+}
+
+// NewBasicAuth creates a new BasicAuth admin plugin. Supported config
+// fields are "username" and "password", both mandatory.
+func NewBasicAuth(cfg config.Config) (plugin.Plugin, error) {
+	username, _ := cfg["username"].(string)
+	password, _ := cfg["password"].(string)
+	if username == "" || password == "" {
+		return nil, plugin.ErrInvalidConfig
+	}
+
+	return &BasicAuth{
+		Base:     plugin.NewBase("basic-auth", cfg),
+		username: username,
+		password: password,
+	}, nil
+}
+
+// Authenticate implements the admin plugin authentication hook.
+func (p *BasicAuth) Authenticate(r *http.Request) bool {
+	username, password, ok := r.BasicAuth()
+	return ok && username == p.username && password == p.password
+}
@@ -0,0 +1,58 @@
+package adminplugin
+
+import (
+	"net/http"
+	"strings"
+
+	"gopkg.in/vinxi/vinxi.v0/config"
+	"gopkg.in/vinxi/vinxi.v0/plugin"
+)
+
+func init() {
+	plugin.Register(plugin.Info{
+		Name:        "bearer-auth",
+		Description: "Protects the admin API with a static bearer token or JWT.",
+		Factory:     NewBearerAuth,
+	})
+}
+
+// BearerAuth implements bearer token authentication for the admin
+// API. If a "secret" config field is set, incoming tokens are
+// verified as HMAC-signed JWTs; otherwise the token is compared
+// directly against the configured "token" field.
+type BearerAuth struct {
+	*plugin.Base
+	token  string
+	secret string
+}
+
+// NewBearerAuth creates a new BearerAuth admin plugin. Supported
+// config fields are "token" (static bearer token) and "secret" (HMAC
+// secret used to verify JWTs instead).
+func NewBearerAuth(cfg config.Config) (plugin.Plugin, error) {
+	token, _ := cfg["token"].(string)
+	secret, _ := cfg["secret"].(string)
+	if token == "" && secret == "" {
+		return nil, plugin.ErrInvalidConfig
+	}
+
+	return &BearerAuth{
+		Base:   plugin.NewBase("bearer-auth", cfg),
+		token:  token,
+		secret: secret,
+	}, nil
+}
+
+// Authenticate implements the admin plugin authentication hook.
+func (p *BearerAuth) Authenticate(r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+	token := strings.TrimPrefix(header, "Bearer ")
+
+	if p.secret != "" {
+		return verifyJWT(token, p.secret)
+	}
+	return token == p.token
+}
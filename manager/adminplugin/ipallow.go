@@ -0,0 +1,69 @@
+package adminplugin
+
+import (
+	"net"
+	"net/http"
+
+	"gopkg.in/vinxi/vinxi.v0/config"
+	"gopkg.in/vinxi/vinxi.v0/plugin"
+)
+
+func init() {
+	plugin.Register(plugin.Info{
+		Name:        "ip-allow",
+		Description: "Restricts the admin API to a configured list of allowed CIDR ranges.",
+		Factory:     NewIPAllow,
+	})
+}
+
+// IPAllow restricts admin API access to a configured allowlist of
+// IPv4/IPv6 CIDR ranges.
+type IPAllow struct {
+	*plugin.Base
+	ranges []*net.IPNet
+}
+
+// NewIPAllow creates a new IPAllow admin plugin. The mandatory
+// "ranges" config field is a list of CIDR strings (e.g.
+// "10.0.0.0/8", "127.0.0.1/32").
+func NewIPAllow(cfg config.Config) (plugin.Plugin, error) {
+	raw, _ := cfg["ranges"].([]interface{})
+	if len(raw) == 0 {
+		return nil, plugin.ErrInvalidConfig
+	}
+
+	ranges := make([]*net.IPNet, 0, len(raw))
+	for _, r := range raw {
+		cidr, ok := r.(string)
+		if !ok {
+			return nil, plugin.ErrInvalidConfig
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, network)
+	}
+
+	return &IPAllow{Base: plugin.NewBase("ip-allow", cfg), ranges: ranges}, nil
+}
+
+// Authenticate implements the admin plugin authentication hook.
+func (p *IPAllow) Authenticate(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range p.ranges {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
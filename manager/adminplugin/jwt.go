@@ -0,0 +1,65 @@
+package adminplugin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// jwtClaims holds the registered JWT claims verifyJWT enforces. Other
+// claims are ignored, since claim validation beyond expiry (e.g.
+// custom audience/issuer checks) is left to a wrapping admin plugin.
+type jwtClaims struct {
+	ExpiresAt int64 `json:"exp"`
+	NotBefore int64 `json:"nbf"`
+}
+
+// verifyJWT checks that token is a well-formed JWT whose HS256
+// signature matches secret and whose exp/nbf claims, if present, place
+// the current time inside the token's validity window.
+func verifyJWT(token, secret string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	signed := parts[0] + "." + parts[1]
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	expected := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(parts[2])) != 1 {
+		return false
+	}
+
+	return claimsValid(parts[1])
+}
+
+// claimsValid decodes the base64url-encoded JWT payload and reports
+// whether the current time falls within its exp/nbf claims, if set.
+// A payload that fails to decode is treated as invalid.
+func claimsValid(payload string) bool {
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return false
+	}
+
+	var claims jwtClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return false
+	}
+
+	now := time.Now().Unix()
+	if claims.ExpiresAt != 0 && now >= claims.ExpiresAt {
+		return false
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return false
+	}
+
+	return true
+}
@@ -16,7 +16,7 @@ func addRoute(method, path string, fn ControllerHandler) {
 	route := &Controller{
 		Path:    path,
 		Method:  method,
-		Handler: fn,
+		Handler: requireAuth(fn),
 	}
 	routes = append(routes, route)
 }
@@ -29,15 +29,6 @@ type JSONRule struct {
 	Metadata    config.Config `json:"metadata,omitempty"`
 }
 
-type JSONPlugin struct {
-	ID          string        `json:"id"`
-	Name        string        `json:"name,omitempty"`
-	Description string        `json:"description,omitempty"`
-	Enabled     bool          `json:"enabled,omitempty"`
-	Config      config.Config `json:"config,omitempty"`
-	Metadata    config.Config `json:"metadata,omitempty"`
-}
-
 type JSONScope struct {
 	ID      string       `json:"id"`
 	Name    string       `json:"name,omitempty"`
@@ -77,6 +68,37 @@ func init() {
 		ctx.SendJSON(info)
 	})
 
+	addRoute("POST", "/config", func(ctx *Context) {
+		var snap Snapshot
+		if err := ctx.ParseBody(&snap); err != nil {
+			return
+		}
+
+		diff, err := ctx.Manager.Apply(snap, ApplyOptions{DryRun: true})
+		if err != nil {
+			ctx.SendError(400, "Cannot diff config: "+err.Error())
+			return
+		}
+
+		ctx.SendJSON(diff)
+	})
+
+	addRoute("PUT", "/config", func(ctx *Context) {
+		var snap Snapshot
+		if err := ctx.ParseBody(&snap); err != nil {
+			return
+		}
+
+		diff, err := ctx.Manager.Apply(snap, ApplyOptions{})
+		if err != nil {
+			ctx.SendError(500, "Cannot apply config: "+err.Error())
+			return
+		}
+
+		ctx.Manager.Persist()
+		ctx.SendJSON(diff)
+	})
+
 	addRoute("GET", "/catalog", func(ctx *Context) {
 		rules := []rule.Info{}
 		for _, rule := range rule.Rules {
@@ -100,11 +122,11 @@ func init() {
 	})
 
 	addRoute("GET", "/plugins", func(ctx *Context) {
-		ctx.SendJSON(createPlugins(ctx.Manager.Plugins.All()))
+		ctx.SendJSON(filterPlugins(ctx, createPlugins(ctx.Manager.Plugins.All())))
 	})
 
 	addRoute("GET", "/scopes", func(ctx *Context) {
-		ctx.SendJSON(createScopes(ctx.Manager.Scopes()))
+		ctx.SendJSON(filterScopes(ctx, createScopes(ctx.Manager.Scopes())))
 	})
 
 	addRoute("GET", "/scopes/:scope", func(ctx *Context) {
@@ -112,7 +134,7 @@ func init() {
 	})
 
 	addRoute("GET", "/instances", func(ctx *Context) {
-		ctx.SendJSON(createInstances(ctx.Manager.Instances(), ctx))
+		ctx.SendJSON(filterInstances(ctx, createInstances(ctx.Manager.Instances(), ctx)))
 	})
 
 	addRoute("GET", "/instances/:instance", func(ctx *Context) {
@@ -121,6 +143,7 @@ func init() {
 
 	addRoute("DELETE", "/instances/:instance", func(ctx *Context) {
 		if ctx.Manager.RemoveInstance(ctx.Instance.ID) {
+			ctx.Manager.Persist()
 			ctx.SendNoContent()
 		} else {
 			ctx.SendError(500, "Cannot remove instance")
@@ -128,7 +151,7 @@ func init() {
 	})
 
 	addRoute("GET", "/instances/:instance/scopes", func(ctx *Context) {
-		ctx.SendJSON(createScopes(ctx.Instance.Scopes()))
+		ctx.SendJSON(filterScopes(ctx, createScopes(ctx.Instance.Scopes())))
 	})
 
 	addRoute("GET", "/instances/:instance/scopes/:scope", func(ctx *Context) {
@@ -137,6 +160,7 @@ func init() {
 
 	addRoute("DELETE", "/instances/:instance/scopes/:scope", func(ctx *Context) {
 		if ctx.Instance.RemoveScope(ctx.Scope.ID) {
+			ctx.Manager.Persist()
 			ctx.SendNoContent()
 		} else {
 			ctx.SendError(500, "Cannot remove scope")
@@ -144,7 +168,7 @@ func init() {
 	})
 
 	addRoute("GET", "/instances/:instance/scopes/:scope/plugins", func(ctx *Context) {
-		ctx.SendJSON(createPlugins(ctx.Scope.Plugins.All()))
+		ctx.SendJSON(filterPlugins(ctx, createPlugins(ctx.Scope.Plugins.All())))
 	})
 
 	addRoute("GET", "/instances/:instance/scopes/:scope/plugins/:plugin", func(ctx *Context) {
@@ -153,14 +177,41 @@ func init() {
 
 	addRoute("DELETE", "/instances/:instance/scopes/:scope/plugins/:plugin", func(ctx *Context) {
 		if ctx.Scope.RemovePlugin(ctx.Plugin.ID()) {
+			ctx.Manager.Persist()
 			ctx.SendNoContent()
 		} else {
 			ctx.SendError(500, "Cannot remove plugin")
 		}
 	})
 
+	addRoute("PATCH", "/plugins/:plugin", PluginsController{}.Patch)
+
+	addRoute("PATCH", "/instances/:instance/scopes/:scope/plugins/:plugin", func(ctx *Context) {
+		type data struct {
+			Config config.Config `json:"config"`
+		}
+
+		var patch data
+		if err := ctx.ParseBody(&patch); err != nil {
+			return
+		}
+
+		instance, err := replaceScopePlugin(ctx.Scope, ctx.Plugin, patch.Config)
+		if err != nil {
+			ctx.SendError(400, "Cannot update plugin: "+err.Error())
+			return
+		}
+
+		ctx.Manager.Persist()
+		ctx.SendJSON(createPlugin(instance))
+	})
+
+	addRoute("POST", "/plugins/:plugin/enable", PluginsController{}.Enable)
+
+	addRoute("POST", "/plugins/:plugin/disable", PluginsController{}.Disable)
+
 	addRoute("GET", "/instances/:instance/scopes/:scope/rules", func(ctx *Context) {
-		ctx.SendJSON(createRules(ctx.Scope))
+		ctx.SendJSON(filterRules(ctx, createRules(ctx.Scope)))
 	})
 
 	addRoute("GET", "/instances/:instance/scopes/:scope/rules/:rule", func(ctx *Context) {
@@ -169,11 +220,32 @@ func init() {
 
 	addRoute("DELETE", "/instances/:instance/scopes/:scope/rules/:rule", func(ctx *Context) {
 		if ctx.Scope.RemoveRule(ctx.Rule.ID()) {
+			ctx.Manager.Persist()
 			ctx.SendNoContent()
 		} else {
 			ctx.SendError(500, "Cannot remove rule")
 		}
 	})
+
+	addRoute("PATCH", "/instances/:instance/scopes/:scope/rules/:rule", func(ctx *Context) {
+		type data struct {
+			Config config.Config `json:"config"`
+		}
+
+		var patch data
+		if err := ctx.ParseBody(&patch); err != nil {
+			return
+		}
+
+		updated, err := replaceRule(ctx.Scope, ctx.Rule, patch.Config)
+		if err != nil {
+			ctx.SendError(400, "Cannot update rule: "+err.Error())
+			return
+		}
+
+		ctx.Manager.Persist()
+		ctx.SendJSON(createRule(updated))
+	})
 }
 
 func createInstance(instance *Instance, ctx *Context) JSONInstance {
@@ -218,14 +290,6 @@ func createRules(scope *Scope) []JSONRule {
 	return rules
 }
 
-func createPlugins(plugins []plugin.Plugin) []JSONPlugin {
-	list := []JSONPlugin{}
-	for i, plugin := range plugins {
-		list[i] = createPlugin(plugin)
-	}
-	return list
-}
-
 func createRule(rule rule.Rule) JSONRule {
 	return JSONRule{
 		ID:          rule.ID(),
@@ -234,13 +298,3 @@ func createRule(rule rule.Rule) JSONRule {
 		Config:      rule.Config(),
 	}
 }
-
-func createPlugin(plugin plugin.Plugin) JSONPlugin {
-	return JSONPlugin{
-		ID:          plugin.ID(),
-		Name:        plugin.Name(),
-		Description: plugin.Description(),
-		Config:      plugin.Config(),
-		Metadata:    plugin.Metadata(),
-	}
-}
@@ -0,0 +1,334 @@
+package manager
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"gopkg.in/vinxi/vinxi.v0/manager/store"
+	"gopkg.in/vinxi/vinxi.v0/utils"
+)
+
+// Role represents an RBAC role that can be attached to an admin Token,
+// constraining which operations it is allowed to perform.
+type Role string
+
+const (
+	// RoleAdmin grants unrestricted access to the admin API.
+	RoleAdmin Role = "admin"
+	// RoleReadOnly grants read-only access to the admin API.
+	RoleReadOnly Role = "readonly"
+	// RoleScoped grants read/write access restricted to a single
+	// instance and/or scope, set via Token.InstanceID/Token.ScopeID.
+	RoleScoped Role = "scoped"
+)
+
+// Token represents an admin API credential and its RBAC scope.
+type Token struct {
+	ID         string `json:"id"`
+	Secret     string `json:"token,omitempty"`
+	Role       Role   `json:"role"`
+	InstanceID string `json:"instance,omitempty"`
+	ScopeID    string `json:"scope,omitempty"`
+}
+
+// redacted returns a copy of the token with its Secret cleared, so it
+// is safe to include in a list response. The creation response is the
+// only place the Secret is ever returned in full.
+func (t Token) redacted() Token {
+	t.Secret = ""
+	return t
+}
+
+// allows reports whether the token permits the given method against
+// the given instance/scope identifiers (empty if not applicable).
+func (t Token) allows(method, instanceID, scopeID string) bool {
+	switch t.Role {
+	case RoleAdmin:
+		return true
+	case RoleReadOnly:
+		return method == "GET" || method == "HEAD"
+	case RoleScoped:
+		if t.InstanceID != "" && t.InstanceID != instanceID {
+			return false
+		}
+		if t.ScopeID != "" && t.ScopeID != scopeID {
+			return false
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// tokensMu guards tokens, read by authenticateToken/Tokens on every
+// admin request and written by CreateToken/RevokeToken/loadTokens.
+var tokensMu sync.RWMutex
+
+// tokens holds the in-memory RBAC token table, persisted via the
+// Manager's dedicated token Store backend (see UseTokenStore) so
+// credentials survive restarts.
+//
+// NOTE: this table is process-global rather than per-Manager, so
+// multiple Manager instances in the same process currently share one
+// set of credentials.
+var tokens = map[string]Token{}
+
+// CreateToken registers a new admin API token with the given role and
+// optional instance/scope restriction, and persists the token table.
+// The returned Token is the only place its plaintext Secret is ever
+// exposed; Tokens redacts it.
+func (m *Manager) CreateToken(role Role, instanceID, scopeID string) Token {
+	t := Token{
+		ID:         utils.NewID(),
+		Secret:     utils.NewID(),
+		Role:       role,
+		InstanceID: instanceID,
+		ScopeID:    scopeID,
+	}
+
+	tokensMu.Lock()
+	tokens[t.ID] = t
+	tokensMu.Unlock()
+
+	m.persistTokens()
+	return t
+}
+
+// Tokens returns the currently registered admin API tokens with their
+// Secret redacted.
+func (m *Manager) Tokens() []Token {
+	tokensMu.RLock()
+	defer tokensMu.RUnlock()
+
+	list := []Token{}
+	for _, t := range tokens {
+		list = append(list, t.redacted())
+	}
+	return list
+}
+
+// RevokeToken removes an admin API token by ID.
+func (m *Manager) RevokeToken(id string) bool {
+	tokensMu.Lock()
+	_, ok := tokens[id]
+	if ok {
+		delete(tokens, id)
+	}
+	tokensMu.Unlock()
+
+	if !ok {
+		return false
+	}
+	m.persistTokens()
+	return true
+}
+
+// persistTokens saves the token table to the Manager's dedicated
+// token Store backend, if configured via UseTokenStore.
+func (m *Manager) persistTokens() {
+	if m.tokenStore == nil {
+		return
+	}
+
+	tokensMu.RLock()
+	data, err := json.Marshal(tokens)
+	tokensMu.RUnlock()
+	if err != nil {
+		return
+	}
+
+	m.tokenStore.Save(data)
+}
+
+// loadTokens restores the token table from the Manager's dedicated
+// token Store backend, if configured via UseTokenStore.
+func (m *Manager) loadTokens() {
+	if m.tokenStore == nil {
+		return
+	}
+	data, err := m.tokenStore.Load()
+	if err != nil {
+		return
+	}
+
+	var restored map[string]Token
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return
+	}
+
+	tokensMu.Lock()
+	tokens = restored
+	tokensMu.Unlock()
+}
+
+// UseTokenStore attaches a dedicated Store backend used to persist
+// the RBAC admin token table, separate from the manager state Store
+// used for Snapshot/Apply so credentials and declarative config can
+// be rotated and backed up independently.
+func (m *Manager) UseTokenStore(s store.Store) error {
+	m.tokenStore = s
+	m.loadTokens()
+	return nil
+}
+
+// matchToken finds the registered Token whose Secret matches the
+// request's bearer token, if any.
+func matchToken(r *http.Request) (Token, bool) {
+	secret := bearerToken(r)
+	if secret == "" {
+		return Token{}, false
+	}
+
+	tokensMu.RLock()
+	defer tokensMu.RUnlock()
+
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Secret), []byte(secret)) == 1 {
+			return t, true
+		}
+	}
+	return Token{}, false
+}
+
+// authenticateToken matches the Authorization bearer token against
+// the RBAC token table and checks it authorizes the requested method
+// against the matched instance/scope, if any.
+func authenticateToken(r *http.Request, instanceID, scopeID string) bool {
+	t, ok := matchToken(r)
+	return ok && t.allows(r.Method, instanceID, scopeID)
+}
+
+// isAdminRequest reports whether the request's bearer token carries
+// RoleAdmin, or whether no tokens have been configured yet (bootstrap
+// mode, matching requireAuth's own bypass below).
+func isAdminRequest(r *http.Request) bool {
+	tokensMu.RLock()
+	empty := len(tokens) == 0
+	tokensMu.RUnlock()
+	if empty {
+		return true
+	}
+
+	t, ok := matchToken(r)
+	return ok && t.Role == RoleAdmin
+}
+
+// tokenCount reports how many admin tokens are currently registered.
+func tokenCount() int {
+	tokensMu.RLock()
+	defer tokensMu.RUnlock()
+	return len(tokens)
+}
+
+// bearerToken extracts the token from a "Bearer <token>" Authorization header.
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if len(h) > len(prefix) && h[:len(prefix)] == prefix {
+		return h[len(prefix):]
+	}
+	return ""
+}
+
+// requireAuth wraps a ControllerHandler so it first runs the admin
+// plugin layer (basic-auth, bearer/JWT, IP allowlist, ...) and the
+// RBAC token check before invoking the original handler. Requests
+// rejected by either are answered with 401 and never reach fn.
+func requireAuth(fn ControllerHandler) ControllerHandler {
+	return func(ctx *Context) {
+		if ctx.AdminPlugins != nil && ctx.AdminPlugins.Len() > 0 {
+			if !runAdminPlugins(ctx) {
+				ctx.SendError(401, "Unauthorized")
+				return
+			}
+		}
+
+		instanceID, scopeID := "", ""
+		if ctx.Instance != nil {
+			instanceID = ctx.Instance.ID
+		}
+		if ctx.Scope != nil {
+			scopeID = ctx.Scope.ID
+		}
+
+		if tokenCount() > 0 && !authenticateToken(ctx.Request, instanceID, scopeID) {
+			ctx.SendError(401, "Unauthorized")
+			return
+		}
+
+		fn(ctx)
+	}
+}
+
+// requireAdmin wraps a ControllerHandler so only a request carrying
+// an admin-role token (or, before any token has been created, the
+// bootstrap request) can reach it. It is used for the /auth/tokens
+// endpoints themselves, since a non-admin token that passes
+// requireAuth (e.g. an unrestricted scoped token) could otherwise
+// mint itself an admin token or revoke other tokens.
+//
+// addRoute already wraps every handler passed to it in requireAuth,
+// so this only adds the extra admin-role gate on top instead of
+// running the admin plugin layer and token check a second time.
+func requireAdmin(fn ControllerHandler) ControllerHandler {
+	return func(ctx *Context) {
+		if !isAdminRequest(ctx.Request) {
+			ctx.SendError(403, "Admin role required")
+			return
+		}
+		fn(ctx)
+	}
+}
+
+// runAdminPlugins runs every plugin registered in the admin plugin
+// layer against the current request, stopping at the first one that
+// rejects it.
+func runAdminPlugins(ctx *Context) bool {
+	for _, p := range ctx.AdminPlugins.All() {
+		auth, ok := p.(interface {
+			Authenticate(*http.Request) bool
+		})
+		if !ok || !p.Enabled() {
+			continue
+		}
+		if !auth.Authenticate(ctx.Request) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	addRoute("GET", "/auth/tokens", requireAdmin(func(ctx *Context) {
+		ctx.SendJSON(ctx.Manager.Tokens())
+	}))
+
+	addRoute("POST", "/auth/tokens", requireAdmin(func(ctx *Context) {
+		type data struct {
+			Role       Role   `json:"role"`
+			InstanceID string `json:"instance,omitempty"`
+			ScopeID    string `json:"scope,omitempty"`
+		}
+
+		var body data
+		if err := ctx.ParseBody(&body); err != nil {
+			return
+		}
+
+		if body.Role == "" {
+			body.Role = RoleReadOnly
+		}
+
+		ctx.SendJSON(ctx.Manager.CreateToken(body.Role, body.InstanceID, body.ScopeID))
+	}))
+
+	addRoute("DELETE", "/auth/tokens/:token", requireAdmin(func(ctx *Context) {
+		if ctx.Manager.RevokeToken(ctx.Params.Get("token")) {
+			ctx.SendNoContent()
+		} else {
+			ctx.SendError(404, "Token not found")
+		}
+	}))
+}
@@ -0,0 +1,47 @@
+package manager
+
+import "testing"
+
+func TestTokenAllows(t *testing.T) {
+	cases := []struct {
+		name       string
+		token      Token
+		method     string
+		instanceID string
+		scopeID    string
+		want       bool
+	}{
+		{"admin allows any method", Token{Role: RoleAdmin}, "DELETE", "i1", "s1", true},
+		{"readonly allows GET", Token{Role: RoleReadOnly}, "GET", "i1", "s1", true},
+		{"readonly allows HEAD", Token{Role: RoleReadOnly}, "HEAD", "i1", "s1", true},
+		{"readonly rejects POST", Token{Role: RoleReadOnly}, "POST", "i1", "s1", false},
+		{"scoped without restriction allows any instance/scope", Token{Role: RoleScoped}, "POST", "i1", "s1", true},
+		{"scoped restricted to instance matches", Token{Role: RoleScoped, InstanceID: "i1"}, "POST", "i1", "s1", true},
+		{"scoped restricted to instance rejects other instance", Token{Role: RoleScoped, InstanceID: "i1"}, "POST", "i2", "s1", false},
+		{"scoped restricted to scope rejects other scope", Token{Role: RoleScoped, ScopeID: "s1"}, "POST", "i1", "s2", false},
+		{"unknown role rejects everything", Token{Role: "bogus"}, "GET", "i1", "s1", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.token.allows(tc.method, tc.instanceID, tc.scopeID); got != tc.want {
+				t.Errorf("allows(%q, %q, %q) = %v, want %v", tc.method, tc.instanceID, tc.scopeID, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTokenRedacted(t *testing.T) {
+	token := Token{ID: "t1", Secret: "super-secret", Role: RoleAdmin}
+
+	redacted := token.redacted()
+	if redacted.Secret != "" {
+		t.Errorf("redacted().Secret = %q, want empty", redacted.Secret)
+	}
+	if token.Secret != "super-secret" {
+		t.Errorf("redacted() mutated the receiver's Secret")
+	}
+	if redacted.ID != token.ID || redacted.Role != token.Role {
+		t.Errorf("redacted() altered non-secret fields: got %+v", redacted)
+	}
+}
@@ -0,0 +1,21 @@
+package manager
+
+import "gopkg.in/vinxi/vinxi.v0/observability"
+
+// MetricsController exposes the Prometheus metrics held by a single
+// Metrics collector: those of every vinxi instance recording into it
+// via Vinxi.UseMetrics.
+type MetricsController struct {
+	metrics *observability.Metrics
+}
+
+// NewMetricsController creates a MetricsController backed by the
+// given Metrics collector.
+func NewMetricsController(m *observability.Metrics) *MetricsController {
+	return &MetricsController{metrics: m}
+}
+
+// Get serves the collected metrics in the Prometheus exposition format.
+func (c *MetricsController) Get(ctx *Context) {
+	c.metrics.Handler().ServeHTTP(ctx.Writer, ctx.Request)
+}
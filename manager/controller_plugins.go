@@ -28,6 +28,7 @@ func createPlugin(p plugin.Plugin) JSONPlugin {
 		ID:          p.ID(),
 		Name:        p.Name(),
 		Description: p.Description(),
+		Enabled:     p.Enabled(),
 		Config:      p.Config(),
 		Metadata:    p.Metadata(),
 	}
@@ -43,7 +44,7 @@ func (PluginsController) List(ctx *Context) {
 	} else {
 		layer = ctx.Manager.Plugins
 	}
-	ctx.Send(createPlugins(layer.All()))
+	ctx.Send(filterPlugins(ctx, createPlugins(layer.All())))
 }
 
 func (PluginsController) Get(ctx *Context) {
@@ -52,6 +53,7 @@ func (PluginsController) Get(ctx *Context) {
 
 func (PluginsController) Delete(ctx *Context) {
 	if ctx.Manager.RemovePlugin(ctx.Plugin.ID()) {
+		ctx.Manager.Persist()
 		ctx.SendNoContent()
 	} else {
 		ctx.SendError(500, "Cannot remove plugin")
@@ -88,5 +90,66 @@ func (PluginsController) Create(ctx *Context) {
 	}
 
 	ctx.Manager.UsePlugin(instance)
+	ctx.Manager.Persist()
 	ctx.Send(createPlugin(instance))
+}
+
+// Patch merges the given JSON config into the existing plugin,
+// re-validates it against its factory and atomically swaps it in,
+// without dropping in-flight requests.
+func (PluginsController) Patch(ctx *Context) {
+	type data struct {
+		Config config.Config `json:"config"`
+	}
+
+	var patch data
+	if err := ctx.ParseBody(&patch); err != nil {
+		return
+	}
+
+	instance, err := replacePlugin(ctx.Manager, ctx.Plugin, patch.Config)
+	if err != nil {
+		ctx.SendError(400, "Cannot update plugin: "+err.Error())
+		return
+	}
+
+	ctx.Manager.Persist()
+	ctx.Send(createPlugin(instance))
+}
+
+// Enable re-activates a previously disabled plugin so it participates
+// in the request flow again.
+func (PluginsController) Enable(ctx *Context) {
+	ctx.Plugin.SetEnabled(true)
+	ctx.Manager.Persist()
+	ctx.Send(createPlugin(ctx.Plugin))
+}
+
+// Disable deactivates a plugin so traffic bypasses it without
+// removing it from the scope/instance.
+func (PluginsController) Disable(ctx *Context) {
+	ctx.Plugin.SetEnabled(false)
+	ctx.Manager.Persist()
+	ctx.Send(createPlugin(ctx.Plugin))
+}
+
+// replacePlugin merges patch into the current plugin's config,
+// re-runs the plugin factory to validate it and swaps the resulting
+// instance into the Manager in place of the previous one.
+func replacePlugin(m *Manager, current plugin.Plugin, patch config.Config) (plugin.Plugin, error) {
+	factory := plugin.Get(current.Name())
+	if factory == nil {
+		return nil, errPluginNotFound
+	}
+
+	instance, err := factory(mergeConfig(current.Config(), patch))
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.ReplacePlugin(current.ID(), instance) {
+		return nil, errPluginReplace
+	}
+
+	return instance, nil
 }
\ No newline at end of file
@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"testing"
+
+	"gopkg.in/vinxi/vinxi.v0/config"
+	"gopkg.in/vinxi/vinxi.v0/plugin"
+)
+
+// fakePlugin is a minimal plugin.Plugin implementation used to
+// exercise createPlugin/createPlugins without depending on any
+// concrete plugin package.
+type fakePlugin struct {
+	id      string
+	name    string
+	enabled bool
+	cfg     config.Config
+}
+
+var _ plugin.Plugin = (*fakePlugin)(nil)
+
+func (p *fakePlugin) ID() string              { return p.id }
+func (p *fakePlugin) Name() string            { return p.name }
+func (p *fakePlugin) Description() string     { return "" }
+func (p *fakePlugin) Enabled() bool           { return p.enabled }
+func (p *fakePlugin) SetEnabled(enabled bool) { p.enabled = enabled }
+func (p *fakePlugin) Config() config.Config   { return p.cfg }
+func (p *fakePlugin) Metadata() config.Config { return nil }
+
+// TestCreatePluginsEmpty checks that an empty input yields a non-nil
+// empty slice, so list endpoints encode it as [] rather than null.
+func TestCreatePluginsEmpty(t *testing.T) {
+	list := createPlugins(nil)
+	if list == nil {
+		t.Error("createPlugins(nil) must return a non-nil empty slice, not nil")
+	}
+	if len(list) != 0 {
+		t.Errorf("createPlugins(nil) = %v, want empty", list)
+	}
+}
+
+// TestCreatePlugins is a regression test for a panic where
+// createPlugins wrote into list[i] on a zero-length slice instead of
+// appending, which crashed every list endpoint (GET /plugins and
+// friends) as soon as a scope/instance/manager had a plugin attached.
+func TestCreatePlugins(t *testing.T) {
+	plugins := []plugin.Plugin{
+		&fakePlugin{id: "p1", name: "cors", enabled: true, cfg: config.Config{"origin": "*"}},
+		&fakePlugin{id: "p2", name: "rate-limit", enabled: false},
+	}
+
+	list := createPlugins(plugins)
+	if len(list) != 2 {
+		t.Fatalf("createPlugins returned %d entries, want 2", len(list))
+	}
+
+	if list[0].ID != "p1" || list[0].Name != "cors" || !list[0].Enabled {
+		t.Errorf("unexpected first entry: %+v", list[0])
+	}
+	if list[1].ID != "p2" || list[1].Name != "rate-limit" || list[1].Enabled {
+		t.Errorf("unexpected second entry: %+v", list[1])
+	}
+}
@@ -0,0 +1,214 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"net/url"
+	"sync"
+
+	"gopkg.in/vinxi/vinxi.v0/trace"
+)
+
+// mustJSON marshals v to JSON, falling back to an empty object on
+// error so a single bad event never breaks the /debug/tap stream.
+func mustJSON(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
+
+// tapHistory keeps a short replay buffer of recent trace events so a
+// new /debug/tap subscriber immediately sees some context instead of
+// an empty stream. It is only fed while at least one /debug/tap
+// client is connected: a permanent background subscriber would keep
+// trace.Active() true forever and defeat its purpose as a hot-path
+// guard for the rest of the proxy.
+var tapHistory = trace.NewRing(256)
+
+var (
+	tapHistoryMu   sync.Mutex
+	tapHistoryRefs int
+	tapHistoryStop func()
+)
+
+// acquireTapHistory starts the background collector feeding
+// tapHistory on the first concurrent /debug/tap client and is a
+// no-op for subsequent ones; releaseTapHistory must be called once
+// per acquireTapHistory call to stop it once the last client leaves.
+func acquireTapHistory() {
+	tapHistoryMu.Lock()
+	defer tapHistoryMu.Unlock()
+
+	tapHistoryRefs++
+	if tapHistoryRefs > 1 {
+		return
+	}
+
+	events, unsubscribe := trace.Subscribe(256)
+	done := make(chan struct{})
+	tapHistoryStop = func() {
+		close(done)
+		unsubscribe()
+	}
+
+	go func() {
+		for {
+			select {
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				tapHistory.Add(ev)
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// releaseTapHistory reverses a prior acquireTapHistory call, stopping
+// the background collector once the last /debug/tap client disconnects.
+func releaseTapHistory() {
+	tapHistoryMu.Lock()
+	defer tapHistoryMu.Unlock()
+
+	tapHistoryRefs--
+	if tapHistoryRefs == 0 && tapHistoryStop != nil {
+		tapHistoryStop()
+		tapHistoryStop = nil
+	}
+}
+
+func init() {
+	addRoute("GET", "/debug/pprof/", func(ctx *Context) {
+		pprof.Index(ctx.Writer, ctx.Request)
+	})
+
+	addRoute("GET", "/debug/pprof/cmdline", func(ctx *Context) {
+		pprof.Cmdline(ctx.Writer, ctx.Request)
+	})
+
+	addRoute("GET", "/debug/pprof/profile", func(ctx *Context) {
+		pprof.Profile(ctx.Writer, ctx.Request)
+	})
+
+	addRoute("GET", "/debug/pprof/symbol", func(ctx *Context) {
+		pprof.Symbol(ctx.Writer, ctx.Request)
+	})
+
+	addRoute("GET", "/debug/pprof/trace", func(ctx *Context) {
+		pprof.Trace(ctx.Writer, ctx.Request)
+	})
+
+	addRoute("GET", "/debug/configz", func(ctx *Context) {
+		resolved, err := resolveConfig(ctx)
+		if err != nil {
+			ctx.SendError(400, "Invalid url: "+err.Error())
+			return
+		}
+		ctx.SendJSON(resolved)
+	})
+
+	addRoute("GET", "/debug/tap", func(ctx *Context) {
+		streamTap(ctx)
+	})
+}
+
+// ResolvedConfig describes which scopes, rules and plugins would fire
+// for a given simulated request, in evaluation order.
+type ResolvedConfig struct {
+	Method  string       `json:"method"`
+	URL     string       `json:"url"`
+	Scopes  []JSONScope  `json:"matchedScopes"`
+	Rules   []JSONRule   `json:"matchedRules"`
+	Plugins []JSONPlugin `json:"ranPlugins"`
+}
+
+// resolveConfig inspects the query parameters "url" and "method" and
+// reports which scopes/rules/plugins would handle a simulated request
+// matching them, across every instance managed by the Manager. The
+// simulated request is built from scratch rather than reusing the
+// live ctx.Request, since that describes this /debug/configz call
+// itself, not the request the caller is asking to resolve.
+func resolveConfig(ctx *Context) (ResolvedConfig, error) {
+	method := ctx.Query("method")
+	if method == "" {
+		method = "GET"
+	}
+	rawURL := ctx.Query("url")
+
+	resolved := ResolvedConfig{Method: method, URL: rawURL}
+
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return resolved, err
+	}
+
+	req := &http.Request{Method: method, URL: target, Host: target.Host, Header: http.Header{}}
+
+	for _, scope := range ctx.Manager.Scopes() {
+		rules := scope.Rules.All()
+
+		matched := true
+		matchedRules := make([]JSONRule, 0, len(rules))
+		for _, r := range rules {
+			if !r.Match(req) {
+				matched = false
+				break
+			}
+			matchedRules = append(matchedRules, createRule(r))
+		}
+
+		if matched {
+			resolved.Rules = append(resolved.Rules, matchedRules...)
+			resolved.Scopes = append(resolved.Scopes, createScope(scope))
+			resolved.Plugins = append(resolved.Plugins, createPlugins(scope.Plugins.All())...)
+		}
+	}
+
+	return resolved, nil
+}
+
+// streamTap serves trace events over Server-Sent Events, one JSON
+// record per request phase, replaying recent history before streaming
+// live events until the client disconnects. Events emitted while the
+// client's buffer is full are dropped, not queued.
+func streamTap(ctx *Context) {
+	acquireTapHistory()
+	defer releaseTapHistory()
+
+	ctx.Writer.Header().Set("Content-Type", "text/event-stream")
+	ctx.Writer.Header().Set("Cache-Control", "no-cache")
+	ctx.Writer.Header().Set("Connection", "keep-alive")
+
+	flusher, ok := ctx.Writer.(interface{ Flush() })
+	writeEvent := func(ev trace.Event) {
+		fmt.Fprintf(ctx.Writer, "data: %s\n\n", mustJSON(ev))
+		if ok {
+			flusher.Flush()
+		}
+	}
+
+	for _, ev := range tapHistory.Snapshot() {
+		writeEvent(ev)
+	}
+
+	events, unsubscribe := trace.Subscribe(64)
+	defer unsubscribe()
+
+	for {
+		select {
+		case ev, open := <-events:
+			if !open {
+				return
+			}
+			writeEvent(ev)
+		case <-ctx.Request.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"errors"
+
+	"gopkg.in/vinxi/vinxi.v0/config"
+	"gopkg.in/vinxi/vinxi.v0/plugin"
+	"gopkg.in/vinxi/vinxi.v0/rule"
+)
+
+var (
+	errPluginNotFound = errors.New("vinxi: plugin not found")
+	errPluginReplace  = errors.New("vinxi: cannot replace plugin")
+	errRuleNotFound   = errors.New("vinxi: rule not found")
+	errRuleReplace    = errors.New("vinxi: cannot replace rule")
+)
+
+// replaceScopePlugin merges patch into the current plugin's config,
+// re-runs the plugin factory to validate it and swaps the resulting
+// instance into the scope in place of the previous one.
+func replaceScopePlugin(scope *Scope, current plugin.Plugin, patch config.Config) (plugin.Plugin, error) {
+	factory := plugin.Get(current.Name())
+	if factory == nil {
+		return nil, errPluginNotFound
+	}
+
+	instance, err := factory(mergeConfig(current.Config(), patch))
+	if err != nil {
+		return nil, err
+	}
+
+	if !scope.ReplacePlugin(current.ID(), instance) {
+		return nil, errPluginReplace
+	}
+
+	return instance, nil
+}
+
+// replaceRule merges patch into the current rule's config, re-runs
+// the rule factory to validate it and swaps the resulting instance
+// into the scope in place of the previous one.
+func replaceRule(scope *Scope, current rule.Rule, patch config.Config) (rule.Rule, error) {
+	if !rule.Exists(current.Name()) {
+		return nil, errRuleNotFound
+	}
+
+	updated := rule.Init(current.Name(), mergeConfig(current.Config(), patch))
+
+	if !scope.ReplaceRule(current.ID(), updated) {
+		return nil, errRuleReplace
+	}
+
+	return updated, nil
+}
+
+// mergeConfig shallow merges patch into base, overwriting any key
+// present in both, and returns the resulting config. base is left
+// untouched.
+func mergeConfig(base, patch config.Config) config.Config {
+	merged := config.Config{}
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range patch {
+		merged[key] = value
+	}
+	return merged
+}
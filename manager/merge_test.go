@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/vinxi/vinxi.v0/config"
+)
+
+func TestMergeConfig(t *testing.T) {
+	base := config.Config{"a": 1, "b": "keep"}
+	patch := config.Config{"b": "overwritten", "c": true}
+
+	merged := mergeConfig(base, patch)
+
+	want := config.Config{"a": 1, "b": "overwritten", "c": true}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("mergeConfig(%v, %v) = %v, want %v", base, patch, merged, want)
+	}
+
+	if base["b"] != "keep" {
+		t.Errorf("mergeConfig mutated base: %v", base)
+	}
+}
+
+func TestMergeConfigEmptyPatch(t *testing.T) {
+	base := config.Config{"a": 1}
+
+	merged := mergeConfig(base, config.Config{})
+	if !reflect.DeepEqual(merged, base) {
+		t.Errorf("mergeConfig with empty patch = %v, want %v", merged, base)
+	}
+}
@@ -0,0 +1,25 @@
+package manager
+
+import "gopkg.in/vinxi/vinxi.v0/observability"
+
+func init() {
+	addRoute("GET", "/metrics", func(ctx *Context) {
+		ctx.Manager.metricsController().Get(ctx)
+	})
+}
+
+// UseMetrics attaches the given Metrics collector to the Manager so
+// its values (and those of every vinxi.Vinxi instance sharing it via
+// Vinxi.UseMetrics) are served at GET /metrics.
+func (m *Manager) UseMetrics(metrics *observability.Metrics) {
+	m.metrics = metrics
+}
+
+// metricsController lazily creates the Manager's Metrics collector if
+// UseMetrics was never called, so /metrics always responds.
+func (m *Manager) metricsController() *MetricsController {
+	if m.metrics == nil {
+		m.metrics = observability.NewMetrics()
+	}
+	return NewMetricsController(m.metrics)
+}
@@ -0,0 +1,183 @@
+package manager
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listOptions captures the common "?name=&enabled=&limit=&offset=&sort="
+// query parameters read by every List endpoint. name, limit and offset
+// apply to every list; enabled filters plugin lists only. sort is
+// parsed but not applied: every list is ordered by ID.
+type listOptions struct {
+	name    string
+	enabled *bool
+	limit   int
+	offset  int
+	sort    string
+}
+
+// parseListOptions reads the pagination/filtering query parameters off
+// the current request. limit defaults to 0 (no limit).
+func parseListOptions(ctx *Context) listOptions {
+	opts := listOptions{
+		name: ctx.Query("name"),
+		sort: ctx.Query("sort"),
+	}
+
+	if v := ctx.Query("enabled"); v != "" {
+		b := v == "true" || v == "1"
+		opts.enabled = &b
+	}
+
+	if v := ctx.Query("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			opts.limit = n
+		}
+	}
+
+	if v := ctx.Query("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			opts.offset = n
+		}
+	}
+
+	return opts
+}
+
+// page slices [offset:offset+limit] out of a total count, clamping to
+// bounds, and returns the resulting [start, end) range.
+func page(total int, opts listOptions) (start, end int) {
+	start = opts.offset
+	if start > total {
+		start = total
+	}
+
+	end = total
+	if opts.limit > 0 && start+opts.limit < end {
+		end = start + opts.limit
+	}
+
+	return start, end
+}
+
+// writePageHeaders sets X-Total-Count and the RFC5988 Link header
+// (rel="next"/"prev") describing the page currently being returned.
+func writePageHeaders(ctx *Context, total int, opts listOptions) {
+	ctx.Writer.Header().Set("X-Total-Count", strconv.Itoa(total))
+
+	links := []string{}
+	if opts.limit > 0 {
+		if opts.offset+opts.limit < total {
+			links = append(links, linkHeader(ctx, opts.offset+opts.limit, opts.limit, "next"))
+		}
+		if opts.offset > 0 {
+			prevOffset := opts.offset - opts.limit
+			if prevOffset < 0 {
+				prevOffset = 0
+			}
+			links = append(links, linkHeader(ctx, prevOffset, opts.limit, "prev"))
+		}
+	}
+
+	if len(links) > 0 {
+		ctx.Writer.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// linkHeader builds a single RFC5988 Link header entry pointing at the
+// current request URL with offset/limit replaced.
+func linkHeader(ctx *Context, offset, limit int, rel string) string {
+	u := *ctx.Request.URL
+	q := u.Query()
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(limit))
+	u.RawQuery = q.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, (&url.URL{Path: u.Path, RawQuery: u.RawQuery}).String(), rel)
+}
+
+// filterPlugins applies name/enabled filtering, stable ID sort and
+// pagination to a plugin list, writing the resulting page headers.
+func filterPlugins(ctx *Context, list []JSONPlugin) []JSONPlugin {
+	opts := parseListOptions(ctx)
+
+	filtered := list[:0:0]
+	for _, p := range list {
+		if opts.name != "" && p.Name != opts.name {
+			continue
+		}
+		if opts.enabled != nil && p.Enabled != *opts.enabled {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].ID < filtered[j].ID })
+
+	writePageHeaders(ctx, len(filtered), opts)
+	start, end := page(len(filtered), opts)
+	return filtered[start:end]
+}
+
+// filterScopes applies name filtering, stable ID sort and pagination
+// to a scope list, writing the resulting page headers.
+func filterScopes(ctx *Context, list []JSONScope) []JSONScope {
+	opts := parseListOptions(ctx)
+
+	filtered := list[:0:0]
+	for _, s := range list {
+		if opts.name != "" && s.Name != opts.name {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].ID < filtered[j].ID })
+
+	writePageHeaders(ctx, len(filtered), opts)
+	start, end := page(len(filtered), opts)
+	return filtered[start:end]
+}
+
+// filterInstances applies name filtering, stable ID sort and
+// pagination to an instance list, writing the resulting page headers.
+func filterInstances(ctx *Context, list []JSONInstance) []JSONInstance {
+	opts := parseListOptions(ctx)
+
+	filtered := list[:0:0]
+	for _, i := range list {
+		if opts.name != "" && i.Name != opts.name {
+			continue
+		}
+		filtered = append(filtered, i)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].ID < filtered[j].ID })
+
+	writePageHeaders(ctx, len(filtered), opts)
+	start, end := page(len(filtered), opts)
+	return filtered[start:end]
+}
+
+// filterRules applies name filtering, stable ID sort and pagination
+// to a rule list, writing the resulting page headers.
+func filterRules(ctx *Context, list []JSONRule) []JSONRule {
+	opts := parseListOptions(ctx)
+
+	filtered := list[:0:0]
+	for _, r := range list {
+		if opts.name != "" && r.Name != opts.name {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].ID < filtered[j].ID })
+
+	writePageHeaders(ctx, len(filtered), opts)
+	start, end := page(len(filtered), opts)
+	return filtered[start:end]
+}
@@ -0,0 +1,158 @@
+package manager
+
+import (
+	"fmt"
+
+	"gopkg.in/vinxi/vinxi.v0/plugin"
+	"gopkg.in/vinxi/vinxi.v0/rule"
+)
+
+// reconcileInstance creates or updates a single instance (and its
+// scopes, rules and plugins) so it matches the given JSONInstance,
+// leaving plugins/rules matched by ID whose content hash is unchanged
+// untouched so they are not needlessly recreated.
+func reconcileInstance(m *Manager, snap JSONInstance) error {
+	instance := m.GetInstance(snap.ID)
+	if instance == nil {
+		instance = m.CreateInstance(snap.Name, snap.Description)
+	}
+
+	if err := pruneScopes(instance, snap.Scopes); err != nil {
+		return err
+	}
+
+	for _, scopeSnap := range snap.Scopes {
+		if err := reconcileScope(instance, scopeSnap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneScopes removes every scope on the instance that is not present
+// in the snapshot being applied, so a declarative apply that drops a
+// scope actually stops it instead of leaving it running.
+func pruneScopes(instance *Instance, snap []JSONScope) error {
+	wanted := map[string]bool{}
+	for _, scope := range snap {
+		wanted[scope.ID] = true
+	}
+
+	for _, scope := range instance.Scopes() {
+		if wanted[scope.ID] {
+			continue
+		}
+		if !instance.RemoveScope(scope.ID) {
+			return fmt.Errorf("vinxi: cannot remove scope %s", scope.ID)
+		}
+	}
+	return nil
+}
+
+// reconcileScope creates or updates a scope and reconciles its rules
+// and plugins against the given JSONScope.
+func reconcileScope(instance *Instance, snap JSONScope) error {
+	scope := instance.GetScope(snap.ID)
+	if scope == nil {
+		scope = instance.CreateScope(snap.Name)
+	}
+
+	if err := reconcilePlugins(scope, snap.Plugins); err != nil {
+		return err
+	}
+
+	return reconcileRules(scope, snap.Rules)
+}
+
+// reconcilePlugins diffs the scope's current plugins against the
+// snapshot by ID, removing plugins no longer present, recreating ones
+// whose (name, enabled, config) hash changed and leaving unchanged
+// ones untouched. Keying by ID (rather than folding every plugin into
+// a single hash-keyed map) means two distinct plugins that happen to
+// share the same (name, enabled, config) are reconciled independently
+// instead of collapsing into one.
+func reconcilePlugins(scope *Scope, snap []JSONPlugin) error {
+	current := map[string]JSONPlugin{}
+	for _, p := range createPlugins(scope.Plugins.All()) {
+		current[p.ID] = p
+	}
+
+	keep := map[string]bool{}
+
+	for _, p := range snap {
+		if existing, ok := current[p.ID]; ok && p.ID != "" {
+			keep[p.ID] = true
+			if pluginHash(existing) == pluginHash(p) {
+				continue
+			}
+			if !scope.RemovePlugin(p.ID) {
+				return fmt.Errorf("vinxi: cannot remove plugin %s", p.ID)
+			}
+		}
+
+		factory := plugin.Get(p.Name)
+		if factory == nil {
+			return fmt.Errorf("vinxi: plugin '%s' does not exist", p.Name)
+		}
+		instance, err := factory(p.Config)
+		if err != nil {
+			return fmt.Errorf("vinxi: cannot create plugin '%s': %s", p.Name, err)
+		}
+		instance.SetEnabled(p.Enabled)
+		scope.UsePlugin(instance)
+	}
+
+	for id := range current {
+		if keep[id] {
+			continue
+		}
+		if !scope.RemovePlugin(id) {
+			return fmt.Errorf("vinxi: cannot remove plugin %s", id)
+		}
+	}
+
+	return nil
+}
+
+// reconcileRules diffs the scope's current rules against the snapshot
+// by ID, removing rules no longer present, recreating ones whose
+// (name, config) hash changed and leaving unchanged ones untouched.
+// Keying by ID, rather than a hash-keyed map, keeps duplicate rules
+// with identical (name, config) from collapsing into a single entry.
+func reconcileRules(scope *Scope, snap []JSONRule) error {
+	current := map[string]JSONRule{}
+	for _, r := range createRules(scope) {
+		current[r.ID] = r
+	}
+
+	keep := map[string]bool{}
+
+	for _, r := range snap {
+		if existing, ok := current[r.ID]; ok && r.ID != "" {
+			keep[r.ID] = true
+			if ruleHash(existing) == ruleHash(r) {
+				continue
+			}
+			if !scope.RemoveRule(r.ID) {
+				return fmt.Errorf("vinxi: cannot remove rule %s", r.ID)
+			}
+		}
+
+		if !rule.Exists(r.Name) {
+			return fmt.Errorf("vinxi: rule '%s' does not exist", r.Name)
+		}
+		scope.UseRule(rule.Init(r.Name, r.Config))
+	}
+
+	for id := range current {
+		if keep[id] {
+			continue
+		}
+		if !scope.RemoveRule(id) {
+			return fmt.Errorf("vinxi: cannot remove rule %s", id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package manager
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Snapshot represents a declarative description of the manager's
+// instances and what they hold (scopes, rules and plugins) that can be
+// exported from a running Manager or submitted to reconcile it.
+// Plugins attached directly to the Manager (Manager.Plugins) are not
+// part of it: Export and Persist do not record them and Apply does not
+// change them.
+type Snapshot struct {
+	Instances []JSONInstance `json:"instances"`
+}
+
+// ApplyOptions configures how a Snapshot is reconciled against the
+// current Manager state.
+type ApplyOptions struct {
+	// DryRun, if enabled, computes the Diff without mutating the Manager.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Diff represents the set of changes required to move the current
+// Manager state to a given Snapshot.
+type Diff struct {
+	Added     []string `json:"added,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+	Updated   []string `json:"updated,omitempty"`
+	Unchanged []string `json:"unchanged,omitempty"`
+}
+
+// IsEmpty reports whether the diff does not require any mutation.
+func (d Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Updated) == 0
+}
+
+// configHash computes a stable content hash over the given parts,
+// used to detect unchanged entities by identity (e.g. name, enabled
+// flag, config) instead of their generated ID.
+func configHash(parts ...interface{}) string {
+	buf, _ := json.Marshal(parts)
+	sum := sha1.Sum(buf)
+	return hex.EncodeToString(sum[:])
+}
+
+// pluginHash computes the identity hash of a plugin snapshot used to
+// detect unchanged plugins, folding in the Enabled flag so toggling it
+// is treated as a change instead of being masked by an identical config.
+func pluginHash(p JSONPlugin) string {
+	return configHash(p.Name, p.Enabled, p.Config)
+}
+
+// ruleHash computes the identity hash of a rule snapshot used to
+// detect unchanged rules.
+func ruleHash(r JSONRule) string {
+	return configHash(r.Name, r.Config)
+}
+
+// Export builds a Snapshot describing the current Manager state.
+func (m *Manager) Export() Snapshot {
+	return Snapshot{Instances: createInstances(m.Instances(), nil)}
+}
+
+// Diff computes the changes required to reconcile the current Manager
+// state with the given Snapshot, without applying them.
+func (m *Manager) Diff(snap Snapshot) Diff {
+	diff := Diff{}
+
+	current := map[string]JSONInstance{}
+	for _, instance := range createInstances(m.Instances(), nil) {
+		current[instance.ID] = instance
+	}
+
+	seen := map[string]bool{}
+	for _, instance := range snap.Instances {
+		seen[instance.ID] = true
+		prev, exists := current[instance.ID]
+		if !exists {
+			diff.Added = append(diff.Added, instance.ID)
+			continue
+		}
+		if instanceHash(prev) == instanceHash(instance) {
+			diff.Unchanged = append(diff.Unchanged, instance.ID)
+		} else {
+			diff.Updated = append(diff.Updated, instance.ID)
+		}
+	}
+
+	for id := range current {
+		if !seen[id] {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+
+	return diff
+}
+
+// instanceHash computes a content hash for an instance snapshot based
+// on its scopes, rules and plugins, ignoring volatile/generated fields
+// (IDs) so re-applying the same declarative config after a round-trip
+// that regenerated IDs does not falsely report the instance as updated.
+func instanceHash(instance JSONInstance) string {
+	return configHash(instance.Name, stripScopeIDs(instance.Scopes))
+}
+
+// stripScopeIDs returns a copy of scopes with every generated ID
+// (scope, rule, plugin) zeroed out, leaving only the fields that
+// reflect the declared configuration.
+func stripScopeIDs(scopes []JSONScope) []JSONScope {
+	stripped := make([]JSONScope, len(scopes))
+	for i, scope := range scopes {
+		rules := make([]JSONRule, len(scope.Rules))
+		for j, r := range scope.Rules {
+			r.ID = ""
+			rules[j] = r
+		}
+
+		plugins := make([]JSONPlugin, len(scope.Plugins))
+		for j, p := range scope.Plugins {
+			p.ID = ""
+			plugins[j] = p
+		}
+
+		stripped[i] = JSONScope{Name: scope.Name, Rules: rules, Plugins: plugins}
+	}
+	return stripped
+}
+
+// Apply reconciles the Manager state with the given Snapshot, adding,
+// removing and updating instances/scopes/rules/plugins as required.
+// Unchanged entities (matched by ID, skipped if their content hash is
+// unchanged) are left untouched. If opts.DryRun is set, the Diff is
+// computed but no mutation is performed.
+//
+// If a non-dry-run apply fails partway through, the Manager is rolled
+// back by re-reconciling the snapshot taken before the apply started.
+// This restores every instance that survived the failed apply to its
+// original ID, but an instance that was itself removed mid-apply
+// before the failure is recreated from scratch and gets a new,
+// different ID, since there is no ID-preserving instance constructor.
+// Its name, description, scopes, rules and plugins are still fully
+// restored.
+func (m *Manager) Apply(snap Snapshot, opts ApplyOptions) (Diff, error) {
+	diff := m.Diff(snap)
+	if opts.DryRun || diff.IsEmpty() {
+		return diff, nil
+	}
+
+	rollback := m.Export()
+	if err := m.reconcile(snap, diff); err != nil {
+		if _, rbErr := m.reconcile(rollback, m.Diff(rollback)); rbErr != nil {
+			return diff, fmt.Errorf("vinxi: apply failed (%s) and rollback failed (%s)", err, rbErr)
+		}
+		return diff, err
+	}
+
+	return diff, nil
+}
+
+// reconcile mutates the Manager so its state matches the given
+// Snapshot, using the previously computed Diff to decide which
+// instances must be added, removed or updated.
+func (m *Manager) reconcile(snap Snapshot, diff Diff) error {
+	removed := map[string]bool{}
+	for _, id := range diff.Removed {
+		removed[id] = true
+	}
+
+	for _, instance := range m.Instances() {
+		if removed[instance.ID] {
+			if !m.RemoveInstance(instance.ID) {
+				return fmt.Errorf("vinxi: cannot remove instance %s", instance.ID)
+			}
+		}
+	}
+
+	for _, snapshot := range snap.Instances {
+		if err := reconcileInstance(m, snapshot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
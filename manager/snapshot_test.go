@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"testing"
+
+	"gopkg.in/vinxi/vinxi.v0/config"
+)
+
+func TestDiffIsEmpty(t *testing.T) {
+	if !(Diff{}).IsEmpty() {
+		t.Error("zero-value Diff should be empty")
+	}
+	if (Diff{Added: []string{"a"}}).IsEmpty() {
+		t.Error("Diff with Added entries should not be empty")
+	}
+	if (Diff{Unchanged: []string{"a"}}).IsEmpty() {
+		t.Error("Diff.IsEmpty must ignore Unchanged")
+	}
+}
+
+func TestPluginHashFoldsEnabled(t *testing.T) {
+	enabled := JSONPlugin{Name: "cors", Enabled: true, Config: config.Config{"origin": "*"}}
+	disabled := enabled
+	disabled.Enabled = false
+
+	if pluginHash(enabled) == pluginHash(disabled) {
+		t.Error("pluginHash must treat toggling Enabled as a change")
+	}
+}
+
+func TestPluginHashStableAcrossIDs(t *testing.T) {
+	a := JSONPlugin{ID: "p1", Name: "cors", Enabled: true, Config: config.Config{"origin": "*"}}
+	b := a
+	b.ID = "p2"
+
+	if pluginHash(a) != pluginHash(b) {
+		t.Error("pluginHash must not depend on the plugin ID")
+	}
+}
+
+func TestRuleHashStableAcrossIDs(t *testing.T) {
+	a := JSONRule{ID: "r1", Name: "path", Config: config.Config{"path": "/api"}}
+	b := a
+	b.ID = "r2"
+
+	if ruleHash(a) != ruleHash(b) {
+		t.Error("ruleHash must not depend on the rule ID")
+	}
+}
+
+func TestStripScopeIDsZeroesGeneratedIDs(t *testing.T) {
+	scopes := []JSONScope{{
+		ID:      "scope-1",
+		Name:    "api",
+		Rules:   []JSONRule{{ID: "rule-1", Name: "path"}},
+		Plugins: []JSONPlugin{{ID: "plugin-1", Name: "cors"}},
+	}}
+
+	stripped := stripScopeIDs(scopes)
+
+	if len(stripped) != 1 {
+		t.Fatalf("stripScopeIDs returned %d scopes, want 1", len(stripped))
+	}
+	if stripped[0].Rules[0].ID != "" || stripped[0].Plugins[0].ID != "" {
+		t.Errorf("stripScopeIDs left a generated ID in place: %+v", stripped[0])
+	}
+	if stripped[0].Name != "api" {
+		t.Errorf("stripScopeIDs dropped the scope name: %+v", stripped[0])
+	}
+
+	// The input must not be mutated.
+	if scopes[0].Rules[0].ID != "rule-1" || scopes[0].Plugins[0].ID != "plugin-1" {
+		t.Error("stripScopeIDs mutated its input")
+	}
+}
+
+func TestInstanceHashIgnoresGeneratedIDs(t *testing.T) {
+	a := JSONInstance{
+		ID:   "instance-1",
+		Name: "edge",
+		Scopes: []JSONScope{{
+			ID:      "scope-1",
+			Name:    "api",
+			Rules:   []JSONRule{{ID: "rule-1", Name: "path"}},
+			Plugins: []JSONPlugin{{ID: "plugin-1", Name: "cors", Enabled: true}},
+		}},
+	}
+
+	b := a
+	b.ID = "instance-2"
+	b.Scopes = []JSONScope{{
+		ID:      "scope-2",
+		Name:    "api",
+		Rules:   []JSONRule{{ID: "rule-2", Name: "path"}},
+		Plugins: []JSONPlugin{{ID: "plugin-2", Name: "cors", Enabled: true}},
+	}}
+
+	if instanceHash(a) != instanceHash(b) {
+		t.Error("instanceHash must not depend on instance/scope/rule/plugin IDs")
+	}
+
+	c := b
+	c.Scopes = []JSONScope{{
+		ID:      "scope-2",
+		Name:    "api",
+		Rules:   []JSONRule{{ID: "rule-2", Name: "path"}},
+		Plugins: []JSONPlugin{{ID: "plugin-2", Name: "cors", Enabled: false}},
+	}}
+
+	if instanceHash(b) == instanceHash(c) {
+		t.Error("instanceHash must reflect a changed plugin Enabled flag")
+	}
+}
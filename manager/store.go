@@ -0,0 +1,88 @@
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/vinxi/vinxi.v0/manager/store"
+)
+
+// UseStore attaches a Store backend to the Manager. If the store
+// already holds a persisted Snapshot it is loaded and applied,
+// otherwise the Manager's current state is persisted as the initial
+// snapshot. A background loop is started to reconcile remote changes
+// (e.g. made by another cluster member) into the in-memory Manager.
+func (m *Manager) UseStore(s store.Store) error {
+	m.store = s
+
+	data, err := s.Load()
+	switch err {
+	case nil:
+		var snap Snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return err
+		}
+		if _, err := m.Apply(snap, ApplyOptions{}); err != nil {
+			return err
+		}
+	case store.ErrNotFound:
+		if err := m.Persist(); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	go m.watchStore()
+	return nil
+}
+
+// Persist saves the current Manager state to the attached Store, if
+// any, remembering the payload so watchStore can recognize and skip
+// the change notification the store fires back in response to it
+// instead of reconciling this Manager against itself.
+func (m *Manager) Persist() error {
+	if m.store == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(m.Export())
+	if err != nil {
+		return err
+	}
+
+	if err := m.store.Save(data); err != nil {
+		return err
+	}
+
+	m.lastSavedMu.Lock()
+	m.lastSaved = data
+	m.lastSavedMu.Unlock()
+
+	return nil
+}
+
+// watchStore reconciles remote state changes into the in-memory
+// Manager for as long as the Manager is running, ignoring changes
+// that merely echo back this Manager's own last Persist call.
+func (m *Manager) watchStore() {
+	changes, err := m.store.Watch(m.done)
+	if err != nil {
+		return
+	}
+
+	for data := range changes {
+		m.lastSavedMu.Lock()
+		self := bytes.Equal(data, m.lastSaved)
+		m.lastSavedMu.Unlock()
+		if self {
+			continue
+		}
+
+		var snap Snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			continue
+		}
+		m.Apply(snap, ApplyOptions{})
+	}
+}
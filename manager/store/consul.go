@@ -0,0 +1,98 @@
+package store
+
+import (
+	"time"
+
+	consul "github.com/hashicorp/consul/api"
+)
+
+func init() {
+	Register("consul", func(uri string) (Store, error) {
+		_, rest, _ := splitScheme(uri)
+		endpoint, key := splitHostKey(rest)
+		return NewConsulStore(endpoint, trimLeadingSlash(key))
+	})
+}
+
+// trimLeadingSlash trims a single leading slash from a Consul KV key.
+func trimLeadingSlash(key string) string {
+	if len(key) > 0 && key[0] == '/' {
+		return key[1:]
+	}
+	return key
+}
+
+// ConsulStore implements Store backed by a Consul KV entry, suitable
+// for clustered deployments that already run Consul for discovery.
+type ConsulStore struct {
+	client *consul.Client
+	key    string
+}
+
+// NewConsulStore creates a new ConsulStore persisting state under key
+// in the Consul KV store reachable at addr.
+func NewConsulStore(addr, key string) (*ConsulStore, error) {
+	config := consul.DefaultConfig()
+	config.Address = addr
+
+	client, err := consul.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConsulStore{client: client, key: key}, nil
+}
+
+// Load retrieves the state stored under the configured KV key.
+func (s *ConsulStore) Load() ([]byte, error) {
+	pair, _, err := s.client.KV().Get(s.key, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pair == nil {
+		return nil, ErrNotFound
+	}
+	return pair.Value, nil
+}
+
+// Save persists the state under the configured KV key.
+func (s *ConsulStore) Save(data []byte) error {
+	_, err := s.client.KV().Put(&consul.KVPair{Key: s.key, Value: data}, nil)
+	return err
+}
+
+// Watch long-polls the Consul KV key for changes using blocking queries.
+func (s *ConsulStore) Watch(stop <-chan struct{}) (<-chan []byte, error) {
+	changes := make(chan []byte)
+
+	go func() {
+		defer close(changes)
+
+		var lastIndex uint64
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			pair, meta, err := s.client.KV().Get(s.key, &consul.QueryOptions{
+				WaitIndex: lastIndex,
+				WaitTime:  30 * time.Second,
+			})
+			if err != nil {
+				time.Sleep(time.Second)
+				continue
+			}
+			if meta.LastIndex == lastIndex || pair == nil {
+				lastIndex = meta.LastIndex
+				continue
+			}
+
+			lastIndex = meta.LastIndex
+			changes <- pair.Value
+		}
+	}()
+
+	return changes, nil
+}
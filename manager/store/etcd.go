@@ -0,0 +1,90 @@
+package store
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/coreos/etcd/clientv3"
+)
+
+func init() {
+	Register("etcd", func(uri string) (Store, error) {
+		_, rest, _ := splitScheme(uri)
+		endpoint, key := splitHostKey(rest)
+		return NewEtcdStore([]string{endpoint}, key)
+	})
+}
+
+// EtcdStore implements Store backed by an etcd v3 key, suitable for
+// clustered deployments that already run etcd.
+type EtcdStore struct {
+	client *clientv3.Client
+	key    string
+}
+
+// NewEtcdStore creates a new EtcdStore persisting state under key,
+// connecting to the given etcd endpoints.
+func NewEtcdStore(endpoints []string, key string) (*EtcdStore, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EtcdStore{client: client, key: key}, nil
+}
+
+// Load retrieves the state stored under the configured key.
+func (s *EtcdStore) Load() ([]byte, error) {
+	resp, err := s.client.Get(context.Background(), s.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, ErrNotFound
+	}
+	return resp.Kvs[0].Value, nil
+}
+
+// Save persists the state under the configured key.
+func (s *EtcdStore) Save(data []byte) error {
+	_, err := s.client.Put(context.Background(), s.key, string(data))
+	return err
+}
+
+// Watch subscribes to changes on the configured key.
+func (s *EtcdStore) Watch(stop <-chan struct{}) (<-chan []byte, error) {
+	changes := make(chan []byte)
+	watch := s.client.Watch(context.Background(), s.key)
+
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case <-stop:
+				return
+			case resp, ok := <-watch:
+				if !ok {
+					return
+				}
+				for _, ev := range resp.Events {
+					changes <- ev.Kv.Value
+				}
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// splitHostKey splits a "host:port/key" remainder into its endpoint and key parts.
+func splitHostKey(rest string) (endpoint, key string) {
+	i := strings.Index(rest, "/")
+	if i == -1 {
+		return rest, "/vinxi/state"
+	}
+	return rest[:i], rest[i:]
+}
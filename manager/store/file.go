@@ -0,0 +1,93 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+func init() {
+	Register("file", func(uri string) (Store, error) {
+		_, path, _ := splitScheme(uri)
+		if path == "" {
+			path = uri
+		}
+		return NewFileStore(path), nil
+	})
+}
+
+// FileStore implements Store by persisting state as a single JSON
+// document on the local filesystem, suitable for single-instance or
+// development deployments.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a new FileStore persisting state at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the state from disk, returning ErrNotFound if it does not exist yet.
+func (s *FileStore) Load() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Save atomically writes the state to disk.
+func (s *FileStore) Save(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Watch polls the file for modifications, since the local filesystem
+// offers no native change notification primitive here.
+func (s *FileStore) Watch(stop <-chan struct{}) (<-chan []byte, error) {
+	changes := make(chan []byte)
+
+	go func() {
+		defer close(changes)
+
+		var lastMod time.Time
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(s.path)
+				if err != nil {
+					continue
+				}
+				if info.ModTime().Equal(lastMod) {
+					continue
+				}
+				lastMod = info.ModTime()
+
+				data, err := s.Load()
+				if err != nil {
+					continue
+				}
+				changes <- data
+			}
+		}
+	}()
+
+	return changes, nil
+}
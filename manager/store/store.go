@@ -0,0 +1,72 @@
+// Package store provides pluggable persistence backends for the
+// manager state (instances, scopes, rules and plugins), allowing a
+// Manager to survive restarts and stay in sync across a cluster.
+package store
+
+import "errors"
+
+// ErrNotFound is returned by Load when the backend has no state stored yet.
+var ErrNotFound = errors.New("store: not found")
+
+// Store represents the persistence backend interface implemented by
+// the built-in drivers (file, etcd, Consul) and any custom driver.
+// State is stored and retrieved as an opaque, already serialized
+// manager.Snapshot payload so drivers do not need to depend on the
+// manager package.
+type Store interface {
+	// Load retrieves the last persisted state. It returns ErrNotFound
+	// if no state has been saved yet.
+	Load() ([]byte, error)
+	// Save persists the given state, replacing any previous value.
+	Save(data []byte) error
+	// Watch returns a channel that emits the new state every time it
+	// changes remotely (e.g. another cluster member calling Save).
+	// The channel is closed when the given stop channel is closed.
+	Watch(stop <-chan struct{}) (<-chan []byte, error)
+}
+
+// Driver represents a Store factory function registered by name so
+// Store instances can be created generically from a URL scheme.
+type Driver func(uri string) (Store, error)
+
+// drivers stores the registered Store drivers by URL scheme.
+var drivers = make(map[string]Driver)
+
+// Register registers a Store driver under the given URL scheme
+// (e.g. "file", "etcd", "consul").
+func Register(scheme string, driver Driver) {
+	drivers[scheme] = driver
+}
+
+// Open creates a new Store from a URI whose scheme selects the
+// registered driver (e.g. "file:///var/lib/vinxi/state.json",
+// "etcd://127.0.0.1:2379/vinxi", "consul://127.0.0.1:8500/vinxi").
+func Open(uri string) (Store, error) {
+	scheme, _, ok := splitScheme(uri)
+	if !ok {
+		return nil, errors.New("store: invalid uri: " + uri)
+	}
+
+	driver, ok := drivers[scheme]
+	if !ok {
+		return nil, errors.New("store: unknown driver: " + scheme)
+	}
+
+	return driver(uri)
+}
+
+// splitScheme splits a URI into its scheme and remainder.
+func splitScheme(uri string) (scheme, rest string, ok bool) {
+	for i := 0; i < len(uri); i++ {
+		switch {
+		case uri[i] == ':':
+			if i+2 < len(uri) && uri[i+1] == '/' && uri[i+2] == '/' {
+				return uri[:i], uri[i+3:], true
+			}
+			return "", "", false
+		case uri[i] == '/':
+			return "", "", false
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,52 @@
+package observability
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer wraps an OpenTelemetry tracer to start the parent span for
+// an incoming request and a child span via StartPlugin for each named
+// phase it flows through, propagating the trace context into the
+// forwarded request. It always reads and writes the W3C traceparent,
+// plus any other format (e.g. B3) registered globally via
+// otel.SetTextMapPropagator.
+type Tracer struct {
+	tracer trace.Tracer
+	prop   propagation.TextMapPropagator
+}
+
+// NewTracer creates a Tracer using the global OpenTelemetry tracer
+// provider, named after the given instrumentation name.
+func NewTracer(name string) *Tracer {
+	return &Tracer{
+		tracer: otel.Tracer(name),
+		prop:   propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, otel.GetTextMapPropagator()),
+	}
+}
+
+// StartRequest extracts any inbound trace context (W3C traceparent or
+// B3, depending on the configured propagator), starts the parent span
+// for the request and returns the request with the span's context
+// attached along with a func to end the span.
+func (t *Tracer) StartRequest(r *http.Request) (*http.Request, func()) {
+	ctx := t.prop.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	ctx, span := t.tracer.Start(ctx, r.Method+" "+r.URL.Path)
+	return r.WithContext(ctx), func() { span.End() }
+}
+
+// StartPlugin starts a child span for a single plugin/rule invocation
+// within the given request's trace context.
+func (t *Tracer) StartPlugin(r *http.Request, name string) func() {
+	_, span := t.tracer.Start(r.Context(), "plugin:"+name)
+	return func() { span.End() }
+}
+
+// Inject writes the current trace context from r into outbound, so
+// the forwarded request carries the same traceparent downstream.
+func (t *Tracer) Inject(r, outbound *http.Request) {
+	t.prop.Inject(r.Context(), propagation.HeaderCarrier(outbound.Header))
+}
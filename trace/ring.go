@@ -0,0 +1,43 @@
+package trace
+
+import "sync"
+
+// Ring is a fixed-capacity, concurrency-safe ring buffer of the most
+// recently emitted Events, used to give late /debug/tap subscribers a
+// bit of history instead of starting from an empty stream.
+type Ring struct {
+	mu    sync.Mutex
+	buf   []Event
+	next  int
+	count int
+}
+
+// NewRing creates a new Ring holding up to size events.
+func NewRing(size int) *Ring {
+	return &Ring{buf: make([]Event, size)}
+}
+
+// Add appends ev to the ring, overwriting the oldest entry once full.
+func (r *Ring) Add(ev Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf[r.next] = ev
+	r.next = (r.next + 1) % len(r.buf)
+	if r.count < len(r.buf) {
+		r.count++
+	}
+}
+
+// Snapshot returns the buffered events in chronological order.
+func (r *Ring) Snapshot() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Event, 0, r.count)
+	start := (r.next - r.count + len(r.buf)) % len(r.buf)
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.buf[(start+i)%len(r.buf)])
+	}
+	return out
+}
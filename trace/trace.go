@@ -0,0 +1,84 @@
+// Package trace instruments the vinxi request/middleware pipeline
+// with per-request, per-phase timing events, keyed by request ID, so
+// debug/introspection tooling (the manager's /debug/tap endpoint) can
+// subscribe to a live sample of traffic without coupling the core
+// proxy to any particular debug transport.
+package trace
+
+import (
+	"sync"
+	"time"
+)
+
+// Event represents a single phase transition observed while handling
+// a request (e.g. entering a plugin, a rule match, the final forward).
+type Event struct {
+	// RequestID correlates every Event belonging to the same request.
+	RequestID string `json:"requestId"`
+	// Phase identifies the middleware phase the event belongs to
+	// (e.g. "request", "response", "error").
+	Phase string `json:"phase"`
+	// Component names the entity that produced the event (a plugin,
+	// rule or scope name), if any.
+	Component string `json:"component,omitempty"`
+	// Method and URL describe the request being processed.
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Timestamp is the Unix nanosecond time the event was recorded.
+	Timestamp int64 `json:"timestamp"`
+	// Duration is how long Component took to run, if applicable.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// subscriber is a channel registered via Subscribe that receives a
+// copy of every emitted Event until it is unsubscribed.
+type subscriber chan Event
+
+var (
+	mu   sync.RWMutex
+	subs = map[subscriber]bool{}
+)
+
+// Emit broadcasts ev to every active subscriber. It never blocks: a
+// subscriber that is not draining its channel fast enough simply
+// misses events, since tracing must never slow down the proxy path.
+func Emit(ev Event) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for sub := range subs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a new listener for trace events and returns the
+// channel to read them from along with an unsubscribe function that
+// must be called once the caller is done listening.
+func Subscribe(buffer int) (<-chan Event, func()) {
+	sub := make(subscriber, buffer)
+
+	mu.Lock()
+	subs[sub] = true
+	mu.Unlock()
+
+	unsubscribe := func() {
+		mu.Lock()
+		delete(subs, sub)
+		mu.Unlock()
+		close(sub)
+	}
+
+	return sub, unsubscribe
+}
+
+// Active reports whether at least one subscriber is currently
+// listening, letting hot paths skip Event construction entirely when
+// no debug client is attached.
+func Active() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return len(subs) > 0
+}
@@ -1,15 +1,21 @@
 package vinxi
 
 import (
+	"bufio"
+	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"runtime"
+	"time"
 
 	"gopkg.in/vinxi/vinxi.v0/context"
 	"gopkg.in/vinxi/vinxi.v0/forward"
 	"gopkg.in/vinxi/vinxi.v0/layer"
 	"gopkg.in/vinxi/vinxi.v0/mux"
+	"gopkg.in/vinxi/vinxi.v0/observability"
 	"gopkg.in/vinxi/vinxi.v0/router"
+	"gopkg.in/vinxi/vinxi.v0/trace"
 	"gopkg.in/vinxi/vinxi.v0/utils"
 )
 
@@ -71,6 +77,29 @@ type Vinxi struct {
 	Layer *layer.Layer
 	// Router stores the built-in router.
 	Router *router.Router
+	// Metrics stores the optional Prometheus metrics instrumenting
+	// this instance. Nil unless UseMetrics is called.
+	Metrics *observability.Metrics
+	// Tracer stores the optional OpenTelemetry tracer instrumenting
+	// this instance. Nil unless UseTracer is called.
+	Tracer *observability.Tracer
+}
+
+// UseMetrics attaches Prometheus metrics instrumentation to the
+// instance, recording request counters, latency histograms and
+// in-flight gauges for every request handled by ServeHTTP.
+func (v *Vinxi) UseMetrics(m *observability.Metrics) *Vinxi {
+	v.Metrics = m
+	return v
+}
+
+// UseTracer attaches OpenTelemetry tracing to the instance. Every
+// request gets a parent span, with the inbound W3C traceparent (or
+// B3, where a B3 propagator is registered globally) propagated in and
+// the active trace context propagated out to the forwarded request.
+func (v *Vinxi) UseTracer(t *observability.Tracer) *Vinxi {
+	v.Tracer = t
+	return v
 }
 
 // New creates a new vinxi proxy layer with default fields.
@@ -202,6 +231,95 @@ func (v *Vinxi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	context.Set(r, "vinxi.host", r.Host)
 	// Define target URL
 	r.URL.Host = r.Host
+
+	if trace.Active() {
+		requestID := utils.NewID()
+		context.Set(r, "vinxi.requestId", requestID)
+
+		start := time.Now()
+		trace.Emit(trace.Event{
+			RequestID: requestID,
+			Phase:     "request",
+			Method:    r.Method,
+			URL:       r.URL.String(),
+			Timestamp: start.UnixNano(),
+		})
+		defer func() {
+			trace.Emit(trace.Event{
+				RequestID: requestID,
+				Phase:     "response",
+				Method:    r.Method,
+				URL:       r.URL.String(),
+				Timestamp: time.Now().UnixNano(),
+				Duration:  time.Since(start),
+			})
+		}()
+	}
+
+	// vinxi.scope labels the metrics per scope. It is read here, before
+	// this instance's request phase runs, so only a value set on the
+	// request before it reaches this instance is picked up; a scope set
+	// by this instance's own middleware is not reflected, and the label
+	// is empty otherwise.
+	scope, _ := context.Get(r, "vinxi.scope").(string)
+
+	if v.Tracer != nil {
+		var end func()
+		r, end = v.Tracer.StartRequest(r)
+		defer end()
+		// Propagate the active trace context into the request before
+		// it reaches the forwarder, so the upstream hop is linked to
+		// this span.
+		v.Tracer.Inject(r, r)
+		endForward := v.Tracer.StartPlugin(r, "forward")
+		defer endForward()
+	}
+
+	if v.Metrics != nil {
+		sw := &statusWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		done := v.Metrics.TrackRequest(v.Metadata.ID, scope)
+		doneForward := v.Metrics.TrackPlugin(v.Metadata.ID, scope, "forward")
+		defer func() {
+			doneForward()
+			done(sw.statusCode)
+		}()
+		w = sw
+	}
+
 	// Run the incoming request middleware layer
 	v.Layer.Run("request", w, r, nil)
 }
+
+// statusWriter wraps an http.ResponseWriter to capture the status
+// code written, so metrics can bucket it into an outcome label.
+type statusWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+// WriteHeader records the status code before delegating to the
+// wrapped ResponseWriter.
+func (w *statusWriter) WriteHeader(code int) {
+	w.statusCode = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush implements http.Flusher by delegating to the wrapped
+// ResponseWriter, if it supports it, so streaming/chunked responses
+// are not broken by metrics instrumentation.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker by delegating to the wrapped
+// ResponseWriter, if it supports it, so protocol upgrades (e.g.
+// WebSocket) are not broken by metrics instrumentation.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("vinxi: underlying ResponseWriter does not support Hijack")
+	}
+	return h.Hijack()
+}